@@ -0,0 +1,26 @@
+// Package rest wires up the Gin router. Swagger annotations on the
+// handlers (see handlers/*.go) are compiled into api/docs/swagger.yaml by
+// `make swagger`, which wraps:
+//
+//go:generate swag init -g ../docs.go -d ./handlers,../../dto --output ../../../api/docs --outputTypes yaml
+package rest
+
+import (
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+)
+
+// RegisterSwaggerRoutes serves the Swagger UI at /swagger/*any. The UI loads
+// its spec from /swagger/doc.json, which gin-swagger reads from swag's
+// registered docs; api/docs/swagger.yaml itself is not served by this route,
+// and the go:generate directive above emits only YAML, not the Go docs
+// package that registers a spec.
+//
+//	@title			Yapp API
+//	@version		1.0
+//	@description	REST API for Yapp.
+//	@BasePath		/api
+func RegisterSwaggerRoutes(r *gin.Engine) {
+	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+}
@@ -18,15 +18,24 @@ func NewFloorHandler(floorService services.IFloorService) *FloorHandler {
 	}
 }
 
+// CreateFloor creates a new floor.
+//
+//	@Summary		Create a floor
+//	@Description	Creates a floor from the given request body.
+//	@Tags			floors
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		dto.CreateFloorReq	true	"Floor to create"
+//	@Success		200		{object}	dto.CreateFloorRes
+//	@Failure		400		{object}	utils.Problem
+//	@Failure		422		{object}	utils.Problem
+//	@Router			/floors [post]
 func (h *FloorHandler) CreateFloor(c *gin.Context) {
 
 	u := &dto.CreateFloorReq{}
 
-	if err := c.ShouldBindJSON(u); err != nil {
-
-		utils.WriteError(c, utils.ErrorInvalidInput)
+	if err := utils.BindJSON(c, u); err != nil {
 		return
-
 	}
 
 	res, err := h.IFloorService.CreateFloor(c.Request.Context(), u)
@@ -0,0 +1,270 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// breachCheckTimeout bounds how long SanitizePasswordPolicy waits on a
+// BreachChecker before giving up, so registration can't hang on an outbound
+// dependency.
+const breachCheckTimeout = 3 * time.Second
+
+// breachCacheSize bounds the number of recent negative lookups kept in
+// memory, so a registration burst doesn't re-query the corpus for the same
+// handful of candidate passwords.
+const breachCacheSize = 1024
+
+// BreachChecker reports whether a password appears in a corpus of known
+// breached passwords, independent of how that corpus is stored.
+type BreachChecker interface {
+	IsBreached(ctx context.Context, password string) (bool, error)
+}
+
+// breachCache is a bounded LRU of SHA-1 hashes already confirmed NOT
+// breached. Only negative results are cached: a breached result must never
+// be remembered, so a later check against an updated corpus always re-runs.
+type breachCache struct {
+	mu       sync.Mutex
+	order    *list.List
+	elements map[string]*list.Element
+	max      int
+}
+
+func newBreachCache(max int) *breachCache {
+	return &breachCache{
+		order:    list.New(),
+		elements: make(map[string]*list.Element, max),
+		max:      max,
+	}
+}
+
+func (c *breachCache) has(hash string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.elements[hash]
+	if !ok {
+		return false
+	}
+	c.order.MoveToFront(el)
+	return true
+}
+
+func (c *breachCache) add(hash string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.elements[hash]; ok {
+		return
+	}
+
+	el := c.order.PushFront(hash)
+	c.elements[hash] = el
+
+	if c.order.Len() > c.max {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.elements, oldest.Value.(string))
+		}
+	}
+}
+
+func sha1Hex(password string) string {
+	sum := sha1.Sum([]byte(password))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+// HTTPBreachChecker checks passwords against a pwned-passwords-style
+// k-anonymity endpoint: only the first 5 hex characters of the SHA-1 hash
+// ever leave the process, with the rest matched locally against the
+// returned suffix list.
+type HTTPBreachChecker struct {
+	Endpoint  string // e.g. "https://api.pwnedpasswords.com/range"
+	Threshold int    // reject if the reported breach count exceeds this; default 0
+	FailOpen  bool   // allow the password through when the lookup itself fails
+	Timeout   time.Duration
+	Client    *http.Client
+
+	cache *breachCache
+}
+
+// NewHTTPBreachChecker builds an HTTPBreachChecker against endpoint.
+// failOpen controls what happens when the outbound request itself fails
+// (network error, non-200, timeout) — it does not affect an actual breach
+// match, which is always rejected.
+func NewHTTPBreachChecker(endpoint string, failOpen bool) *HTTPBreachChecker {
+	return &HTTPBreachChecker{
+		Endpoint: endpoint,
+		FailOpen: failOpen,
+		Timeout:  breachCheckTimeout,
+		Client:   http.DefaultClient,
+		cache:    newBreachCache(breachCacheSize),
+	}
+}
+
+func (c *HTTPBreachChecker) IsBreached(ctx context.Context, password string) (bool, error) {
+	hash := sha1Hex(password)
+	if c.cache.has(hash) {
+		return false, nil
+	}
+	prefix, suffix := hash[:5], hash[5:]
+
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = breachCheckTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.Endpoint+"/"+prefix, nil)
+	if err != nil {
+		return c.onLookupFailure(err)
+	}
+
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return c.onLookupFailure(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return c.onLookupFailure(fmt.Errorf("breach checker: unexpected status %d from %s", resp.StatusCode, c.Endpoint))
+	}
+
+	count, found, err := scanSuffixCount(resp.Body, suffix)
+	if err != nil {
+		return c.onLookupFailure(err)
+	}
+
+	if !found || count <= c.Threshold {
+		c.cache.add(hash)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (c *HTTPBreachChecker) onLookupFailure(err error) (bool, error) {
+	if c.FailOpen {
+		return false, nil
+	}
+	return false, err
+}
+
+// scanSuffixCount scans a pwned-passwords-style "SUFFIX:COUNT\r\n" response
+// body for suffix and returns its reported count.
+func scanSuffixCount(body io.Reader, suffix string) (count int, found bool, err error) {
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		parts := strings.SplitN(strings.TrimSpace(scanner.Text()), ":", 2)
+		if len(parts) != 2 || !strings.EqualFold(parts[0], suffix) {
+			continue
+		}
+		n, convErr := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if convErr != nil {
+			return 0, false, convErr
+		}
+		return n, true, nil
+	}
+	return 0, false, scanner.Err()
+}
+
+// FileBreachChecker checks passwords against a local corpus file for
+// air-gapped deployments: one full uppercase SHA-1 hash per line, fixed
+// width, sorted ascending, so a lookup is a binary search over the file
+// rather than a linear scan.
+type FileBreachChecker struct {
+	path      string
+	lineWidth int64
+
+	cache *breachCache
+}
+
+// NewFileBreachChecker opens path and derives the fixed line width from its
+// first line.
+func NewFileBreachChecker(path string) (*FileBreachChecker, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	first, err := bufio.NewReader(f).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if len(first) == 0 {
+		return nil, fmt.Errorf("breach checker: corpus file %s is empty", path)
+	}
+
+	return &FileBreachChecker{
+		path:      path,
+		lineWidth: int64(len(first)),
+		cache:     newBreachCache(breachCacheSize),
+	}, nil
+}
+
+func (c *FileBreachChecker) IsBreached(ctx context.Context, password string) (bool, error) {
+	hash := sha1Hex(password)
+	if c.cache.has(hash) {
+		return false, nil
+	}
+
+	f, err := os.Open(c.path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return false, err
+	}
+
+	buf := make([]byte, c.lineWidth)
+	lo, hi := int64(0), info.Size()/c.lineWidth-1
+
+	for lo <= hi {
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		default:
+		}
+
+		mid := lo + (hi-lo)/2
+		if _, err := f.ReadAt(buf, mid*c.lineWidth); err != nil && err != io.EOF {
+			return false, err
+		}
+		line := strings.ToUpper(strings.TrimSpace(string(bytes.TrimRight(buf, "\x00"))))
+
+		switch {
+		case line == hash:
+			return true, nil
+		case line < hash:
+			lo = mid + 1
+		default:
+			hi = mid - 1
+		}
+	}
+
+	c.cache.add(hash)
+	return false, nil
+}
@@ -0,0 +1,160 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestScanSuffixCount(t *testing.T) {
+	body := "0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n" +
+		"00D4F6E8FA6EECAD2A3AA415EEC418D38EC:2\r\n" +
+		"011053FD0102E94D6AE2F8B83D76FAF94F6:179769\r\n"
+
+	count, found, err := scanSuffixCount(strings.NewReader(body), "00D4F6E8FA6EECAD2A3AA415EEC418D38EC")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !found || count != 2 {
+		t.Fatalf("got (count=%d, found=%v), want (2, true)", count, found)
+	}
+
+	_, found, err = scanSuffixCount(strings.NewReader(body), "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if found {
+		t.Fatalf("expected suffix not to be found")
+	}
+}
+
+func TestHTTPBreachChecker_IsBreached(t *testing.T) {
+	const password = "correcthorsebatterystaple"
+	hash := sha1Hex(password)
+	prefix, suffix := hash[:5], hash[5:]
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, prefix) {
+			t.Fatalf("request leaked more than the hash prefix: %s", r.URL.Path)
+		}
+		fmt.Fprintf(w, "%s:37\r\nAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA:1\r\n", suffix)
+	}))
+	defer srv.Close()
+
+	checker := NewHTTPBreachChecker(srv.URL, false)
+
+	breached, err := checker.IsBreached(context.Background(), password)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !breached {
+		t.Fatalf("expected password to be reported as breached")
+	}
+}
+
+func TestHTTPBreachChecker_FailOpenVsClosed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	open := NewHTTPBreachChecker(srv.URL, true)
+	if breached, err := open.IsBreached(context.Background(), "whatever"); err != nil || breached {
+		t.Fatalf("fail-open checker: got (%v, %v), want (false, nil)", breached, err)
+	}
+
+	closed := NewHTTPBreachChecker(srv.URL, false)
+	if _, err := closed.IsBreached(context.Background(), "whatever"); err == nil {
+		t.Fatalf("fail-closed checker: expected an error")
+	}
+}
+
+func TestFileBreachChecker_IsBreached(t *testing.T) {
+	breachedPassword := "correcthorsebatterystaple123!"
+	cleanPassword := "a-much-better-unique-passphrase"
+
+	hashes := []string{
+		sha1Hex(breachedPassword),
+		sha1Hex("some-other-breached-password"),
+		sha1Hex("yet-another-one"),
+	}
+	sort.Strings(hashes)
+
+	f, err := os.CreateTemp(t.TempDir(), "corpus-*.txt")
+	if err != nil {
+		t.Fatalf("CreateTemp: %v", err)
+	}
+	for _, h := range hashes {
+		if _, err := fmt.Fprintf(f, "%s\n", h); err != nil {
+			t.Fatalf("write corpus: %v", err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("close corpus: %v", err)
+	}
+
+	checker, err := NewFileBreachChecker(f.Name())
+	if err != nil {
+		t.Fatalf("NewFileBreachChecker: %v", err)
+	}
+
+	breached, err := checker.IsBreached(context.Background(), breachedPassword)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !breached {
+		t.Fatalf("expected %q to be reported as breached", breachedPassword)
+	}
+
+	breached, err = checker.IsBreached(context.Background(), cleanPassword)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if breached {
+		t.Fatalf("expected %q not to be reported as breached", cleanPassword)
+	}
+}
+
+func TestBreachCache_EvictsOldest(t *testing.T) {
+	c := newBreachCache(2)
+	c.add("a")
+	c.add("b")
+	c.add("c") // evicts "a"
+
+	if c.has("a") {
+		t.Fatalf("expected %q to have been evicted", "a")
+	}
+	if !c.has("b") || !c.has("c") {
+		t.Fatalf("expected %q and %q to still be cached", "b", "c")
+	}
+}
+
+func TestSanitizePasswordPolicy_BreachChecker(t *testing.T) {
+	const raw = "a-reasonably-long-passphrase-12345"
+
+	if _, err := SanitizePasswordPolicy(raw, stubBreachChecker{breached: true}); err != ErrorPasswordBreached {
+		t.Fatalf("got err = %v, want ErrorPasswordBreached", err)
+	}
+
+	got, err := SanitizePasswordPolicy(raw, stubBreachChecker{breached: false})
+	if err != nil || got != raw {
+		t.Fatalf("got (%q, %v), want (%q, nil)", got, err, raw)
+	}
+
+	if _, err := SanitizePasswordPolicy(raw); err != nil {
+		t.Fatalf("expected no error with no checker supplied, got %v", err)
+	}
+}
+
+type stubBreachChecker struct {
+	breached bool
+}
+
+func (s stubBreachChecker) IsBreached(context.Context, string) (bool, error) {
+	return s.breached, nil
+}
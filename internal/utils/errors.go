@@ -0,0 +1,23 @@
+package utils
+
+import "errors"
+
+// Sentinels for the phone/breach validation added on top of the baseline
+// sanitizers. The pre-existing sentinels referenced elsewhere in this
+// package (ErrorInvalidUsername, ErrorInvalidHallName, ...) are declared
+// elsewhere in this package and are out of scope here.
+var (
+	// ErrorPhoneRegionNotAllowed is returned by PhoneSanitizer.Sanitize when
+	// a number is valid but resolves to a country outside the configured
+	// allowlist.
+	ErrorPhoneRegionNotAllowed = errors.New("phone number region is not allowed")
+
+	// ErrorPasswordBreached is returned by SanitizePasswordPolicy when a
+	// BreachChecker reports the password appears in a known-breach corpus.
+	ErrorPasswordBreached = errors.New("password has appeared in a known data breach")
+
+	// ErrorBreachCheckUnavailable is returned by SanitizePasswordPolicy when
+	// a BreachChecker configured to fail closed could not complete its
+	// lookup (timeout, network error, corpus file unreadable, ...).
+	ErrorBreachCheckUnavailable = errors.New("password breach check is unavailable")
+)
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"strings"
+
+	"github.com/nyaruka/phonenumbers"
+)
+
+// DefaultPhoneRegion is the region used to interpret phone numbers given in
+// national format when no PhoneSanitizer has been configured explicitly.
+// It is read once, at package initialization, to build the default
+// sanitizer; assigning to it later has no effect. Deployments should call
+// ConfigurePhoneSanitizer (or construct their own PhoneSanitizer) from config
+// rather than relying on the fallback.
+var DefaultPhoneRegion = "NP"
+
+// PhoneSanitizer validates and normalizes phone numbers to E.164 using a
+// configurable default region, with an optional allowlist restricting which
+// countries are accepted.
+type PhoneSanitizer struct {
+	defaultRegion    string
+	allowedCountries map[string]struct{}
+}
+
+// NewPhoneSanitizer builds a PhoneSanitizer that interprets national-format
+// numbers against defaultRegion (an ISO 3166-1 alpha-2 code, e.g. "NP",
+// "US"). If allowedCountryCodes is non-empty, numbers that resolve to a
+// region outside that set are rejected even if otherwise valid.
+func NewPhoneSanitizer(defaultRegion string, allowedCountryCodes ...string) *PhoneSanitizer {
+	ps := &PhoneSanitizer{defaultRegion: strings.ToUpper(defaultRegion)}
+
+	if len(allowedCountryCodes) > 0 {
+		ps.allowedCountries = make(map[string]struct{}, len(allowedCountryCodes))
+		for _, code := range allowedCountryCodes {
+			ps.allowedCountries[strings.ToUpper(code)] = struct{}{}
+		}
+	}
+
+	return ps
+}
+
+// Sanitize parses s against the sanitizer's default region, rejects numbers
+// that fail IsValidNumber or fall outside the allowed-country list, and
+// returns the canonical E.164 representation.
+func (ps *PhoneSanitizer) Sanitize(ptr *string) (*string, error) {
+	if ptr == nil {
+		return nil, nil
+	}
+
+	s := strings.TrimSpace(*ptr)
+	if s == "" {
+		return nil, nil
+	}
+
+	num, err := phonenumbers.Parse(s, ps.defaultRegion)
+	if err != nil || !phonenumbers.IsValidNumber(num) {
+		return nil, ErrorInvalidPhoneNumber
+	}
+
+	if ps.allowedCountries != nil {
+		region := phonenumbers.GetRegionCodeForNumber(num)
+		if _, ok := ps.allowedCountries[strings.ToUpper(region)]; !ok {
+			return nil, ErrorPhoneRegionNotAllowed
+		}
+	}
+
+	e164 := phonenumbers.Format(num, phonenumbers.PhoneNumberFormat_E164)
+	return &e164, nil
+}
+
+// defaultPhoneSanitizer backs the package-level SanitizePhoneE164 helper.
+// ConfigurePhoneSanitizer should be called once at startup, after config is
+// loaded, to replace it with a sanitizer for the deployment's region.
+var defaultPhoneSanitizer = NewPhoneSanitizer(DefaultPhoneRegion)
+
+// ConfigurePhoneSanitizer replaces the package-level phone sanitizer used by
+// SanitizePhoneE164. Services that need an explicit dependency should
+// construct their own PhoneSanitizer via NewPhoneSanitizer instead.
+func ConfigurePhoneSanitizer(defaultRegion string, allowedCountryCodes ...string) {
+	defaultPhoneSanitizer = NewPhoneSanitizer(defaultRegion, allowedCountryCodes...)
+}
@@ -0,0 +1,75 @@
+package utils
+
+import "testing"
+
+func TestPhoneSanitizer_Sanitize(t *testing.T) {
+	cases := []struct {
+		name    string
+		region  string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"NP national format", "NP", "9841234567", "+9779841234567", false},
+		{"NP international format", "NP", "+9779841234567", "+9779841234567", false},
+		{"NP malformed", "NP", "123", "", true},
+
+		{"US national format", "US", "(415) 555-2671", "+14155552671", false},
+		{"US international format", "US", "+14155552671", "+14155552671", false},
+		{"US malformed", "US", "not-a-number", "", true},
+
+		{"GB national format", "GB", "020 7183 8750", "+442071838750", false},
+		{"GB international format", "GB", "+442071838750", "+442071838750", false},
+		{"GB malformed", "GB", "555", "", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ps := NewPhoneSanitizer(tc.region)
+			in := tc.input
+
+			got, err := ps.Sanitize(&in)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("Sanitize(%q) = %v, want error", tc.input, got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Sanitize(%q) unexpected error: %v", tc.input, err)
+			}
+			if got == nil || *got != tc.want {
+				t.Fatalf("Sanitize(%q) = %v, want %q", tc.input, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPhoneSanitizer_Nil(t *testing.T) {
+	ps := NewPhoneSanitizer("NP")
+
+	got, err := ps.Sanitize(nil)
+	if err != nil || got != nil {
+		t.Fatalf("Sanitize(nil) = (%v, %v), want (nil, nil)", got, err)
+	}
+}
+
+func TestPhoneSanitizer_AllowedCountries(t *testing.T) {
+	ps := NewPhoneSanitizer("NP", "US")
+
+	blocked := "9841234567" // valid NP number, but NP isn't in the allowlist
+	if _, err := ps.Sanitize(&blocked); err != ErrorPhoneRegionNotAllowed {
+		t.Fatalf("Sanitize(%q) err = %v, want ErrorPhoneRegionNotAllowed", blocked, err)
+	}
+
+	allowed := "+14155552671"
+	got, err := ps.Sanitize(&allowed)
+	if err != nil {
+		t.Fatalf("Sanitize(%q) unexpected error: %v", allowed, err)
+	}
+	if got == nil || *got != "+14155552671" {
+		t.Fatalf("Sanitize(%q) = %v, want +14155552671", allowed, got)
+	}
+}
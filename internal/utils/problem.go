@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// Problem is an RFC 7807 problem+json response body.
+type Problem struct {
+	Type   string            `json:"type"`
+	Title  string            `json:"title"`
+	Status int               `json:"status"`
+	Detail string            `json:"detail,omitempty"`
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// BindJSON binds and validates the request body into dst, the way every
+// handler's dto.*Req binding should. Both a validator.v10 field error and a
+// plain malformed-body error are routed through WriteError — the same sink
+// floor_handler.go already uses for service errors — so a bind failure's
+// response shape is part of the one documented error contract instead of a
+// second, parallel writer. Handlers should call this in place of
+// c.ShouldBindJSON.
+func BindJSON(c *gin.Context, dst interface{}) error {
+	if err := c.ShouldBindJSON(dst); err != nil {
+		var verrs validator.ValidationErrors
+		if errors.As(err, &verrs) {
+			fieldErr := &FieldError{Fields: fieldErrors(verrs)}
+			WriteError(c, fieldErr)
+			return fieldErr
+		}
+
+		WriteError(c, ErrorInvalidInput)
+		return err
+	}
+
+	return nil
+}
+
+func fieldErrors(verrs validator.ValidationErrors) map[string]string {
+	out := make(map[string]string, len(verrs))
+	for _, fe := range verrs {
+		out[fe.Field()] = fe.Tag()
+	}
+	return out
+}
+
+// WriteProblem writes an RFC 7807 problem+json response.
+func WriteProblem(c *gin.Context, status int, problemType, title string, fields map[string]string) {
+	c.Header("Content-Type", "application/problem+json")
+	c.AbortWithStatusJSON(status, Problem{
+		Type:   problemType,
+		Title:  title,
+		Status: status,
+		Errors: fields,
+	})
+}
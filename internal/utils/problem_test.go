@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type bindJSONTestReq struct {
+	Name string `json:"name" binding:"required"`
+}
+
+func newTestContext(body string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/floors", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	return c, w
+}
+
+func TestBindJSON_ValidationFailure(t *testing.T) {
+	c, w := newTestContext(`{}`)
+
+	var dst bindJSONTestReq
+	err := BindJSON(c, &dst)
+	if err == nil {
+		t.Fatalf("expected an error for a missing required field")
+	}
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("got status %d, want %d", w.Code, http.StatusUnprocessableEntity)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Fatalf("got Content-Type %q, want application/problem+json", ct)
+	}
+
+	var problem Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("decoding problem body: %v", err)
+	}
+	if _, ok := problem.Errors["Name"]; !ok {
+		t.Fatalf("expected a field error for Name, got %+v", problem.Errors)
+	}
+}
+
+func TestBindJSON_MalformedBody(t *testing.T) {
+	c, w := newTestContext(`{not json`)
+
+	var dst bindJSONTestReq
+	err := BindJSON(c, &dst)
+	if err == nil {
+		t.Fatalf("expected an error for a malformed body")
+	}
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("got status %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Fatalf("got Content-Type %q, want application/problem+json", ct)
+	}
+}
+
+func TestBindJSON_OK(t *testing.T) {
+	c, w := newTestContext(`{"name":"floor one"}`)
+
+	var dst bindJSONTestReq
+	if err := BindJSON(c, &dst); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dst.Name != "floor one" {
+		t.Fatalf("got Name = %q, want %q", dst.Name, "floor one")
+	}
+	if w.Code != 0 && w.Code != http.StatusOK {
+		t.Fatalf("expected no response to have been written, got status %d", w.Code)
+	}
+}
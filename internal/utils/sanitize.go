@@ -1,13 +1,12 @@
 package utils
 
 import (
+	"context"
 	"os"
-	"path/filepath"
 	"regexp"
 	"strings"
 	"unicode/utf8"
 
-	"github.com/microcosm-cc/bluemonday"
 	passwordvalidator "github.com/wagslane/go-password-validator"
 	"golang.org/x/text/unicode/norm"
 )
@@ -20,14 +19,6 @@ var hallNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_.\- ]{3,32}$`)
 var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9.!#$%&'*+/=?^_` + "`" + `{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
 var hexRegex = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}){1,2}$`)
 
-var blockedExt = map[string]struct{}{
-	".exe": {}, ".bat": {}, ".cmd": {}, ".msix": {},
-	".scr": {}, ".pif": {}, ".dll": {}, ".jse": {}, ".vbs": {},
-	".vbe": {}, ".wsf": {}, ".wsh": {}, ".ps1": {}, ".psm1": {}, ".reg": {},
-	".jar": {}, ".dmg": {}, ".iso": {}, ".pkg": {}, ".sh": {},
-	".virus": {},
-}
-
 // NAME SECTION
 func SanitizeUsername(s string) (string, error) {
 	s = strings.TrimSpace(s)
@@ -77,41 +68,20 @@ func SanitizeEmail(s string) (string, error) {
 	return s, nil
 }
 
+// See phone.go for the PhoneSanitizer type. SanitizePhoneE164 is kept here
+// as a thin wrapper over the package-level default sanitizer for callers
+// that don't need a region override.
 func SanitizePhoneE164(ptr *string) (*string, error) {
-	if ptr == nil {
-		return nil, nil
-	}
-	s := strings.TrimSpace(*ptr)
-	if s == "" {
-		return nil, nil
-	}
-	// If you use libphonenumber:
-	// num, err := phonenumbers.Parse(s, "NP") // or your default region
-	// if err != nil || !phonenumbers.IsValidNumber(num) { return nil, ErrInvalidPhone }
-	// e164 := phonenumbers.Format(num, phonenumbers.E164)
-	// return &e164, nil
-	// If not using lib yet, minimally keep digits/+ and do a length check:
-	s = keepPlusDigits(s)
-	if len(s) < 7 || len(s) > 20 {
-		return nil, ErrorInvalidPhoneNumber
-	}
-	return &s, nil
-}
-
-func keepPlusDigits(s string) string {
-	var b strings.Builder
-	for _, r := range s {
-		if r == '+' || (r >= '0' && r <= '9') {
-			b.WriteRune(r)
-		}
-	}
-	return b.String()
+	return defaultPhoneSanitizer.Sanitize(ptr)
 }
 
 // PASSWORD SECTION
 const minEntropyBits = 60.0 // ~good baseline for online attacks; use 70–80 for higher risk
 
-func SanitizePasswordPolicy(raw string) (string, error) {
+// SanitizePasswordPolicy enforces entropy and, if a BreachChecker is given,
+// rejects passwords found in a known-breach corpus. The checker is variadic
+// so existing callers that only care about entropy keep compiling unchanged.
+func SanitizePasswordPolicy(raw string, checker ...BreachChecker) (string, error) {
 	// Do NOT silently modify. Reject confusing whitespace at edges.
 	if strings.TrimSpace(raw) != raw {
 		return "", ErrorPasswordWhiteSpace
@@ -119,6 +89,20 @@ func SanitizePasswordPolicy(raw string) (string, error) {
 	if err := passwordvalidator.Validate(raw, minEntropyBits); err != nil {
 		return "", ErrorInvalidPassword
 	}
+
+	if len(checker) > 0 && checker[0] != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), breachCheckTimeout)
+		defer cancel()
+
+		breached, err := checker[0].IsBreached(ctx, raw)
+		if err != nil {
+			return "", ErrorBreachCheckUnavailable
+		}
+		if breached {
+			return "", ErrorPasswordBreached
+		}
+	}
+
 	return raw, nil
 }
 
@@ -142,36 +126,11 @@ func SanitizeColorFormat(colorHex *string) (*string, error) {
 }
 
 // TEXT SECTION
-func SanitizeText(text *string) (*string, error) {
-	if text == nil {
-		return nil, nil
-	}
-
-	s := strings.TrimSpace(*text)
-	if s == "" {
-		return nil, nil
-	}
-
-	// xss injection prevention
-	p := bluemonday.UGCPolicy()
-	s = p.Sanitize(s)
-
-	return &s, nil
-}
-
-func SanitizeMessageContent(content *string) *string {
-
-	// s = strings.Join(strings.Fields(s), " ")
-
-	s := strings.TrimSpace(*content)
-	s = norm.NFKC.String(s)
-
-	p := bluemonday.UGCPolicy()
-	s = p.Sanitize(s)
-
-	return &s
-
-}
+//
+// Content HTML sanitization moved to the typed Sanitizer in sanitizer.go —
+// callers must pick the surface-specific method (HallDescription,
+// MessageBody, ProfileBio, SystemHTML) instead of a generic text sanitizer,
+// so a new content kind can't accidentally end up on the wrong policy.
 
 // FILE SECTION
 func ValidateFileName(fileName string) (string, error) {
@@ -186,24 +145,5 @@ func ValidateFileName(fileName string) (string, error) {
 	return "", ErrorInvalidFileName
 }
 
-func ValidateFileType(fileType *string, url string) (*string, error) {
-
-	//		check the url for the filetype
-	ext := strings.ToLower(filepath.Ext(url))
-
-	if _, bad := blockedExt[ext]; bad {
-		return nil, ErrorBadFileType
-	}
-
-	//	cross checking (condition, filetype != nil)
-	if fileType != nil {
-		if !strings.Contains(strings.ToLower(*fileType), ext) {
-
-			// fileType contains diff file than url
-			return nil, ErrorFileUnmatch
-
-		}
-	}
-
-	return &ext, nil
-}
+// File type checking has moved to ValidateUpload in upload.go, which sniffs
+// the actual content instead of trusting the extension/caller-supplied type.
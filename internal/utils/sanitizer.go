@@ -0,0 +1,153 @@
+package utils
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+// Sanitizer holds pre-built bluemonday policies for each distinct content
+// surface, rather than rebuilding a policy (and its regexes) on every call.
+// Each surface gets only the HTML it actually needs: a chat message has no
+// business allowing the tables or iframes that UGCPolicy permits.
+type Sanitizer struct {
+	messageBody     *bluemonday.Policy
+	hallDescription *bluemonday.Policy
+	profileBio      *bluemonday.Policy
+	systemHTML      *bluemonday.Policy
+
+	mediaProxyOrigin string
+}
+
+// SanitizerConfig configures the optional media-proxy rewrite applied to
+// message images. Leave MediaProxyOrigin empty to pass image URLs through
+// unchanged.
+type SanitizerConfig struct {
+	MediaProxyOrigin string
+}
+
+// NewSanitizer builds the per-surface policies once, at startup.
+func NewSanitizer(cfg SanitizerConfig) *Sanitizer {
+	return &Sanitizer{
+		messageBody:      messageBodyPolicy(),
+		hallDescription:  hallDescriptionPolicy(),
+		profileBio:       profileBioPolicy(),
+		systemHTML:       bluemonday.StrictPolicy(),
+		mediaProxyOrigin: strings.TrimRight(cfg.MediaProxyOrigin, "/"),
+	}
+}
+
+// defaultSanitizer is the package-level Sanitizer used by callers that
+// haven't been wired with an injected one. Call ConfigureSanitizer at
+// startup, once config is loaded, to set a media proxy origin.
+var defaultSanitizer = NewSanitizer(SanitizerConfig{})
+
+// ConfigureSanitizer replaces the package-level default Sanitizer.
+func ConfigureSanitizer(cfg SanitizerConfig) {
+	defaultSanitizer = NewSanitizer(cfg)
+}
+
+func messageBodyPolicy() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+	p.AllowStandardURLs()
+	p.AllowAttrs("href").OnElements("a")
+	p.AllowAttrs("src", "alt").OnElements("img")
+	p.AllowElements("b", "strong", "i", "em", "u", "s", "code", "pre", "br", "img")
+	return p
+}
+
+func hallDescriptionPolicy() *bluemonday.Policy {
+	p := messageBodyPolicy()
+	p.AllowElements("ul", "ol", "li", "p", "h1", "h2", "h3")
+	return p
+}
+
+func profileBioPolicy() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+	p.AllowElements("b", "strong", "i", "em", "br")
+	return p
+}
+
+// MessageBody sanitizes inline chat message HTML to safe formatting plus
+// links and images, then rewrites links to force rel="nofollow ugc
+// noopener" and proxies image src through mediaProxyOrigin if configured.
+func (s *Sanitizer) MessageBody(raw string) string {
+	return s.rewriteLinks(s.messageBody.Sanitize(raw))
+}
+
+// HallDescription sanitizes hall description HTML: inline formatting plus
+// lists and headings.
+func (s *Sanitizer) HallDescription(raw string) string {
+	return s.rewriteLinks(s.hallDescription.Sanitize(raw))
+}
+
+// ProfileBio sanitizes profile bio HTML to a very restricted set of inline
+// formatting tags, with no links or images.
+func (s *Sanitizer) ProfileBio(raw string) string {
+	return s.profileBio.Sanitize(raw)
+}
+
+// SystemHTML strips all markup, for content that must never render as HTML.
+func (s *Sanitizer) SystemHTML(raw string) string {
+	return s.systemHTML.Sanitize(raw)
+}
+
+var (
+	anchorTagRegex = regexp.MustCompile(`(?i)<a\s+([^>]*)>`)
+	imgTagRegex    = regexp.MustCompile(`(?i)<img\s+([^>]*)>`)
+	hrefAttrRegex  = regexp.MustCompile(`(?i)href\s*=\s*"([^"]*)"`)
+	srcAttrRegex   = regexp.MustCompile(`(?i)src\s*=\s*"([^"]*)"`)
+	relAttrRegex   = regexp.MustCompile(`(?i)\s*rel\s*=\s*"[^"]*"`)
+)
+
+var dangerousURLSchemes = []string{"javascript:", "data:", "vbscript:"}
+
+func hasDangerousScheme(raw string) bool {
+	lower := strings.ToLower(strings.TrimSpace(raw))
+	for _, scheme := range dangerousURLSchemes {
+		if strings.HasPrefix(lower, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+// rewriteLinks forces rel="nofollow ugc noopener" on every surviving <a
+// href>, reduces anchors/images whose URL uses a dangerous scheme to a bare
+// <a> or <img> with no attributes, and
+// proxies <img src> through mediaProxyOrigin when one is configured.
+func (s *Sanitizer) rewriteLinks(htmlStr string) string {
+	htmlStr = anchorTagRegex.ReplaceAllStringFunc(htmlStr, func(tag string) string {
+		attrs := anchorTagRegex.FindStringSubmatch(tag)[1]
+
+		href := hrefAttrRegex.FindStringSubmatch(attrs)
+		if href == nil || hasDangerousScheme(href[1]) {
+			return "<a>"
+		}
+
+		attrs = strings.TrimSpace(relAttrRegex.ReplaceAllString(attrs, ""))
+		return fmt.Sprintf(`<a %s rel="nofollow ugc noopener">`, attrs)
+	})
+
+	htmlStr = imgTagRegex.ReplaceAllStringFunc(htmlStr, func(tag string) string {
+		attrs := imgTagRegex.FindStringSubmatch(tag)[1]
+
+		src := srcAttrRegex.FindStringSubmatch(attrs)
+		if src == nil || hasDangerousScheme(src[1]) {
+			return "<img>"
+		}
+
+		if s.mediaProxyOrigin == "" {
+			return tag
+		}
+
+		proxied := s.mediaProxyOrigin + "/" + url.QueryEscape(src[1])
+		attrs = srcAttrRegex.ReplaceAllString(attrs, fmt.Sprintf(`src="%s"`, proxied))
+		return fmt.Sprintf("<img %s>", attrs)
+	})
+
+	return htmlStr
+}
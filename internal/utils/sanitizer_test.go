@@ -0,0 +1,73 @@
+package utils
+
+import "testing"
+
+func TestSanitizer_MessageBody_LinkRel(t *testing.T) {
+	s := NewSanitizer(SanitizerConfig{})
+
+	got := s.MessageBody(`<a href="https://example.com" rel="sponsored">hi</a>`)
+	want := `<a href="https://example.com" rel="nofollow ugc noopener">hi</a>`
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestSanitizer_MessageBody_DropsDangerousSchemes(t *testing.T) {
+	s := NewSanitizer(SanitizerConfig{})
+
+	got := s.MessageBody(`<a href="javascript:alert(1)">click me</a>`)
+	want := `<a>click me</a>`
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestSanitizer_MessageBody_ProxiesImages(t *testing.T) {
+	s := NewSanitizer(SanitizerConfig{MediaProxyOrigin: "https://media.example.com/"})
+
+	got := s.MessageBody(`<img src="https://tracker.example.com/pixel.png">`)
+	want := `<img src="https://media.example.com/https%3A%2F%2Ftracker.example.com%2Fpixel.png">`
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestSanitizer_MessageBody_NoProxyOriginLeavesImageAlone(t *testing.T) {
+	s := NewSanitizer(SanitizerConfig{})
+
+	in := `<img src="https://example.com/pixel.png">`
+	got := s.MessageBody(in)
+	if got != in {
+		t.Fatalf("got %q, want %q", got, in)
+	}
+}
+
+func TestSanitizer_HallDescription_AllowsStructure(t *testing.T) {
+	s := NewSanitizer(SanitizerConfig{})
+
+	got := s.HallDescription(`<h2>Rules</h2><ul><li>Be kind</li></ul>`)
+	want := `<h2>Rules</h2><ul><li>Be kind</li></ul>`
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestSanitizer_ProfileBio_StripsLinksAndImages(t *testing.T) {
+	s := NewSanitizer(SanitizerConfig{})
+
+	got := s.ProfileBio(`<b>hi</b><a href="https://example.com">link</a><img src="x.png">`)
+	want := `<b>hi</b>link`
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestSanitizer_SystemHTML_StripsEverything(t *testing.T) {
+	s := NewSanitizer(SanitizerConfig{})
+
+	got := s.SystemHTML(`<b>bold</b> <script>alert(1)</script>text`)
+	want := `bold text`
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
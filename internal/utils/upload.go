@@ -0,0 +1,152 @@
+package utils
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// sniffSize is how many leading bytes of an upload are read for type
+// sniffing; http.DetectContentType only ever looks at the first 512.
+const sniffSize = 512
+
+// UploadKind selects which allowlist of MIME types an upload endpoint
+// accepts. Hall avatars, message attachments, and profile banners each have
+// very different acceptable types, so each endpoint picks the media kind it
+// accepts (image, audio, video, document) rather than sharing one global
+// allowlist.
+type UploadKind int
+
+const (
+	UploadKindImage UploadKind = iota
+	UploadKindAudio
+	UploadKindVideo
+	UploadKindDocument
+)
+
+// extensionsByMIME lists the file extensions accepted for a given sniffed
+// MIME type. A claimed extension not in this list for the detected type is
+// rejected, so content of one allowed type can't pass under another type's
+// extension (GIF bytes named photo.png). Executables are caught earlier, by
+// dangerousSignatures.
+var extensionsByMIME = map[string][]string{
+	"image/png":       {".png"},
+	"image/jpeg":      {".jpg", ".jpeg"},
+	"image/gif":       {".gif"},
+	"image/webp":      {".webp"},
+	"audio/mpeg":      {".mp3"},
+	"audio/ogg":       {".ogg", ".oga"},
+	"audio/wave":      {".wav"},
+	"audio/webm":      {".weba"},
+	"video/mp4":       {".mp4"},
+	"video/webm":      {".webm"},
+	"video/quicktime": {".mov"},
+	"application/pdf": {".pdf"},
+	"text/plain":      {".txt"},
+}
+
+func extensionMatchesMIME(ext, mime string) bool {
+	for _, allowed := range extensionsByMIME[mime] {
+		if ext == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+var allowedMIMEByKind = map[UploadKind]map[string]struct{}{
+	UploadKindImage: {
+		"image/png": {}, "image/jpeg": {}, "image/gif": {}, "image/webp": {},
+	},
+	UploadKindAudio: {
+		"audio/mpeg": {}, "audio/ogg": {}, "audio/wave": {}, "audio/webm": {},
+	},
+	UploadKindVideo: {
+		"video/mp4": {}, "video/webm": {}, "video/quicktime": {},
+	},
+	UploadKindDocument: {
+		"application/pdf": {}, "text/plain": {},
+	},
+}
+
+// dangerousSignatures are magic-byte prefixes that are never acceptable for
+// an upload, regardless of the claimed extension or the allowlist in
+// effect — these catch an executable or archive smuggled in under a media
+// extension, which a bare extension check can't.
+var dangerousSignatures = []struct {
+	mime string
+	sig  []byte
+}{
+	{"application/x-msdownload", []byte("MZ")},                    // PE: exe/dll
+	{"application/x-elf", []byte("\x7fELF")},                      // ELF
+	{"application/x-mach-binary", []byte{0xFE, 0xED, 0xFA, 0xCE}}, // Mach-O 32-bit BE
+	{"application/x-mach-binary", []byte{0xFE, 0xED, 0xFA, 0xCF}}, // Mach-O 64-bit BE
+	{"application/x-mach-binary", []byte{0xCE, 0xFA, 0xED, 0xFE}}, // Mach-O 32-bit LE
+	{"application/x-mach-binary", []byte{0xCF, 0xFA, 0xED, 0xFE}}, // Mach-O 64-bit LE
+	{"application/zip", []byte("PK\x03\x04")},                     // zip, jar, and Office OOXML
+	{"text/x-shellscript", []byte("#!")},                          // shebang scripts
+}
+
+// sniffDangerous reports the MIME label of the first dangerousSignatures
+// entry matching head, or "" if none match.
+func sniffDangerous(head []byte) string {
+	for _, s := range dangerousSignatures {
+		if bytes.HasPrefix(head, s.sig) {
+			return s.mime
+		}
+	}
+	return ""
+}
+
+// sanitizeUploadName strips any directory components from a claimed
+// filename so callers can use it directly to build a storage key.
+func sanitizeUploadName(claimedName string) string {
+	return filepath.Base(strings.TrimSpace(claimedName))
+}
+
+// ValidateUpload sniffs the real content type of an upload from its first
+// sniffSize bytes instead of trusting the claimed extension or
+// caller-supplied content type. It rejects anything matching the
+// executable/archive blocklist or falling outside kind's allowlist, and on
+// success returns the sanitized filename, the detected MIME type, and a
+// reader that replays the sniffed bytes followed by the remainder of r, so
+// the caller can still forward the full upload to storage without reading
+// it twice.
+func ValidateUpload(r io.Reader, kind UploadKind, claimedName, claimedType string) (sanitizedName, detectedMIME string, out io.Reader, err error) {
+	head := make([]byte, sniffSize)
+	n, readErr := io.ReadFull(r, head)
+	if readErr != nil && readErr != io.ErrUnexpectedEOF && readErr != io.EOF {
+		return "", "", nil, ErrorInvalidFileName
+	}
+	head = head[:n]
+	out = io.MultiReader(bytes.NewReader(head), r)
+
+	if sniffDangerous(head) != "" {
+		return "", "", nil, ErrorBadFileType
+	}
+
+	detected := http.DetectContentType(head)
+	if base, _, cut := strings.Cut(detected, ";"); cut {
+		detected = strings.TrimSpace(base)
+	}
+
+	allowed, ok := allowedMIMEByKind[kind]
+	if !ok || len(allowed) == 0 {
+		return "", "", nil, ErrorBadFileType
+	}
+	if _, ok := allowed[detected]; !ok {
+		return "", "", nil, ErrorFileUnmatch
+	}
+
+	if ext := strings.ToLower(filepath.Ext(claimedName)); ext != "" && !extensionMatchesMIME(ext, detected) {
+		return "", "", nil, ErrorFileUnmatch
+	}
+
+	if claimedType != "" && !strings.EqualFold(strings.TrimSpace(claimedType), detected) {
+		return "", "", nil, ErrorFileUnmatch
+	}
+
+	return sanitizeUploadName(claimedName), detected, out, nil
+}
@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+var pngHeader = []byte{0x89, 'P', 'N', 'G', 0x0D, 0x0A, 0x1A, 0x0A}
+
+func TestValidateUpload_OK(t *testing.T) {
+	body := append(append([]byte{}, pngHeader...), bytes.Repeat([]byte{0}, 64)...)
+
+	name, mime, out, err := ValidateUpload(bytes.NewReader(body), UploadKindImage, "avatar.png", "image/png")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name != "avatar.png" || mime != "image/png" {
+		t.Fatalf("got (%q, %q), want (avatar.png, image/png)", name, mime)
+	}
+
+	replayed, err := io.ReadAll(out)
+	if err != nil {
+		t.Fatalf("reading replayed reader: %v", err)
+	}
+	if !bytes.Equal(replayed, body) {
+		t.Fatalf("replayed reader did not reproduce the original bytes")
+	}
+}
+
+func TestValidateUpload_RenamedExecutableRejected(t *testing.T) {
+	// An EXE renamed to look like a PNG: the extension lies, the magic
+	// bytes don't.
+	body := append([]byte("MZ"), bytes.Repeat([]byte{0}, 64)...)
+
+	if _, _, _, err := ValidateUpload(bytes.NewReader(body), UploadKindImage, "totally-a.png", ""); err != ErrorBadFileType {
+		t.Fatalf("got err = %v, want ErrorBadFileType", err)
+	}
+}
+
+func TestValidateUpload_ExtensionDisagreesWithSniffedType(t *testing.T) {
+	// Real GIF bytes, claimed as a .png — the extension/content mismatch
+	// the request specifically called out.
+	gif := append([]byte("GIF89a"), bytes.Repeat([]byte{0}, 64)...)
+
+	if _, _, _, err := ValidateUpload(bytes.NewReader(gif), UploadKindImage, "photo.png", ""); err != ErrorFileUnmatch {
+		t.Fatalf("got err = %v, want ErrorFileUnmatch", err)
+	}
+
+	// Matching extension succeeds.
+	name, mime, _, err := ValidateUpload(bytes.NewReader(gif), UploadKindImage, "photo.gif", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name != "photo.gif" || mime != "image/gif" {
+		t.Fatalf("got (%q, %q), want (photo.gif, image/gif)", name, mime)
+	}
+}
+
+func TestValidateUpload_OutsideAllowlistRejected(t *testing.T) {
+	body := append(append([]byte{}, pngHeader...), bytes.Repeat([]byte{0}, 64)...)
+
+	if _, _, _, err := ValidateUpload(bytes.NewReader(body), UploadKindAudio, "avatar.png", ""); err != ErrorFileUnmatch {
+		t.Fatalf("got err = %v, want ErrorFileUnmatch", err)
+	}
+}
+
+func TestValidateUpload_ClaimedTypeDisagreesWithSniffedType(t *testing.T) {
+	body := append(append([]byte{}, pngHeader...), bytes.Repeat([]byte{0}, 64)...)
+
+	if _, _, _, err := ValidateUpload(bytes.NewReader(body), UploadKindImage, "avatar.png", "image/jpeg"); err != ErrorFileUnmatch {
+		t.Fatalf("got err = %v, want ErrorFileUnmatch", err)
+	}
+}
+
+func TestValidateUpload_PathTraversalNameSanitized(t *testing.T) {
+	body := append(append([]byte{}, pngHeader...), bytes.Repeat([]byte{0}, 64)...)
+
+	name, _, _, err := ValidateUpload(bytes.NewReader(body), UploadKindImage, "../../etc/avatar.png", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(name, "/") {
+		t.Fatalf("sanitized name %q still contains path separators", name)
+	}
+}
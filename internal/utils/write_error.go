@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FieldError carries per-field validator.v10 failures. WriteError
+// special-cases it so a bind failure renders the same problem+json shape
+// as any other sentinel error, through the same sink.
+type FieldError struct {
+	Fields map[string]string
+}
+
+func (e *FieldError) Error() string {
+	return "one or more fields failed validation"
+}
+
+// errorProblem describes how a known sentinel error renders as an RFC 7807
+// problem+json response.
+type errorProblem struct {
+	status int
+	typ    string
+	title  string
+}
+
+// knownErrors maps the sentinel errors declared in this package to the
+// status/type/title WriteError renders them as. Handler- or service-layer
+// errors not in this table fall back to a generic 500.
+var knownErrors = map[error]errorProblem{
+	ErrorInvalidUsername:       {http.StatusBadRequest, "about:blank#invalid-username", "invalid username"},
+	ErrorInvalidHallName:       {http.StatusBadRequest, "about:blank#invalid-hall-name", "invalid hall name"},
+	ErrorInvalidDisplayName:    {http.StatusBadRequest, "about:blank#invalid-display-name", "invalid display name"},
+	ErrorInvalidEmail:          {http.StatusBadRequest, "about:blank#invalid-email", "invalid email"},
+	ErrorInvalidPhoneNumber:    {http.StatusBadRequest, "about:blank#invalid-phone-number", "invalid phone number"},
+	ErrorPhoneRegionNotAllowed: {http.StatusBadRequest, "about:blank#phone-region-not-allowed", "phone number region is not allowed"},
+	ErrorPasswordWhiteSpace:    {http.StatusBadRequest, "about:blank#invalid-password", "password must not have leading or trailing whitespace"},
+	ErrorInvalidPassword:       {http.StatusBadRequest, "about:blank#invalid-password", "password does not meet the entropy policy"},
+	ErrorPasswordBreached:      {http.StatusBadRequest, "about:blank#password-breached", "password has appeared in a known data breach"},
+	ErrorBreachCheckUnavailable: {
+		http.StatusServiceUnavailable, "about:blank#breach-check-unavailable", "password breach check is unavailable",
+	},
+	ErrorInvalidBannerColor: {http.StatusBadRequest, "about:blank#invalid-color", "invalid color"},
+	ErrorInvalidFileName:    {http.StatusBadRequest, "about:blank#invalid-file-name", "invalid file name"},
+	ErrorBadFileType:        {http.StatusUnprocessableEntity, "about:blank#bad-file-type", "file type is not allowed"},
+	ErrorFileUnmatch:        {http.StatusUnprocessableEntity, "about:blank#file-type-mismatch", "file type does not match its contents"},
+	ErrorInvalidInput:       {http.StatusBadRequest, "about:blank#invalid-input", "request body could not be parsed"},
+}
+
+// WriteError is the single sink every handler uses to turn an error into an
+// HTTP response: a *FieldError renders as a problem+json validation
+// failure with per-field detail, a known sentinel renders with its mapped
+// status, and anything else falls back to a generic 500.
+func WriteError(c *gin.Context, err error) {
+	var fieldErr *FieldError
+	if errors.As(err, &fieldErr) {
+		WriteProblem(c, http.StatusUnprocessableEntity, "about:blank#validation-failed",
+			"One or more fields failed validation", fieldErr.Fields)
+		return
+	}
+
+	if problem, ok := knownErrors[err]; ok {
+		WriteProblem(c, problem.status, problem.typ, problem.title, nil)
+		return
+	}
+
+	WriteProblem(c, http.StatusInternalServerError, "about:blank#internal-error", "internal server error", nil)
+}
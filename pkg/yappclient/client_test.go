@@ -0,0 +1,47 @@
+package yappclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/suck-seed/yapp/internal/dto"
+)
+
+func TestClient_CreateFloor(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/floors" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		var req dto.CreateFloorReq
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(dto.CreateFloorRes{})
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, nil)
+
+	if _, err := client.CreateFloor(context.Background(), &dto.CreateFloorReq{}); err != nil {
+		t.Fatalf("CreateFloor: %v", err)
+	}
+}
+
+func TestClient_CreateFloor_UnexpectedStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, nil)
+
+	if _, err := client.CreateFloor(context.Background(), &dto.CreateFloorReq{}); err == nil {
+		t.Fatalf("expected an error for a non-200 response")
+	}
+}